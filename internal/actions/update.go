@@ -1,6 +1,11 @@
 package actions
 
 import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+
 	"github.com/containrrr/watchtower/internal/util"
 	"github.com/containrrr/watchtower/pkg/container"
 	"github.com/containrrr/watchtower/pkg/lifecycle"
@@ -8,8 +13,96 @@ import (
 	"github.com/containrrr/watchtower/pkg/sorter"
 	"github.com/containrrr/watchtower/pkg/types"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// maxCheckConcurrency bounds the default worker pool size used for stale
+// checks when UpdateParams.CheckConcurrency isn't set.
+const maxCheckConcurrency = 8
+
+// registryPullRate and registryPullBurst bound how fast stale checks may hit
+// any single registry host, so a host with dozens of containers doesn't trip
+// a registry's pull-rate limits.
+const (
+	registryPullRate  = 5
+	registryPullBurst = 10
 )
 
+var (
+	registryLimitersMu sync.Mutex
+	registryLimiters   = map[string]*rate.Limiter{}
+)
+
+func registryLimiterFor(hostname string) *rate.Limiter {
+	registryLimitersMu.Lock()
+	defer registryLimitersMu.Unlock()
+
+	limiter, ok := registryLimiters[hostname]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(registryPullRate), registryPullBurst)
+		registryLimiters[hostname] = limiter
+	}
+	return limiter
+}
+
+// registryHostname extracts the registry hostname an image reference will be
+// pulled from, defaulting to Docker Hub when the reference doesn't name one
+// explicitly (e.g. "nginx" or "library/nginx").
+func registryHostname(imageName string) string {
+	const dockerHubHostname = "index.docker.io"
+
+	name, _, _ := strings.Cut(imageName, "@")
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 1 {
+		return dockerHubHostname
+	}
+	if !strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost" {
+		return dockerHubHostname
+	}
+	return parts[0]
+}
+
+// checkConcurrency resolves the worker pool size for stale checks, defaulting
+// to the number of CPUs capped at maxCheckConcurrency.
+func checkConcurrency(params types.UpdateParams) int {
+	if params.CheckConcurrency > 0 {
+		return params.CheckConcurrency
+	}
+	if numCPU := runtime.NumCPU(); numCPU < maxCheckConcurrency {
+		return numCPU
+	}
+	return maxCheckConcurrency
+}
+
+// staleCheckResult carries everything the sequential summary loop needs to
+// log and account for one container's stale check, once it completes on the
+// worker pool.
+type staleCheckResult struct {
+	stale     bool
+	err       error
+	pinnedTag string
+}
+
+// checkStale resolves whether a single container's image is stale, honoring
+// a pinned image tag override and rate limiting per registry host. It
+// doesn't log anything itself: runs happen on the worker pool in
+// nondeterministic order, so logging is deferred to the sequential loop in
+// Update that processes results in container order.
+func checkStale(targetContainer container.Container, client container.Client, params types.UpdateParams) staleCheckResult {
+	limiter := registryLimiterFor(registryHostname(targetContainer.ImageName()))
+	if err := limiter.Wait(context.Background()); err != nil {
+		return staleCheckResult{err: err}
+	}
+
+	if tag, ok := params.ImageTags[targetContainer.Name()]; ok {
+		err := client.PullImageTag(targetContainer, tag)
+		return staleCheckResult{stale: err == nil, err: err, pinnedTag: tag}
+	}
+
+	stale, err := client.IsContainerStale(targetContainer)
+	return staleCheckResult{stale: stale, err: err}
+}
+
 // Update looks at the running Docker containers to see if any of the images
 // used to start those containers have been updated. If a change is detected in
 // any of the images, the associated containers are stopped and restarted with
@@ -30,8 +123,34 @@ func Update(client container.Client, params types.UpdateParams) (*metrics2.Metri
 
 	staleCheckFailed := 0
 
+	staleResults := make([]staleCheckResult, len(containers))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < checkConcurrency(params); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				staleResults[i] = checkStale(containers[i], client, params)
+			}
+		}()
+	}
+	for i := range containers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Results are processed here, in container order, rather than as each
+	// worker finishes, so logging and metrics stay deterministic regardless
+	// of how the pool interleaves registry calls.
 	for i, targetContainer := range containers {
-		stale, err := client.IsContainerStale(targetContainer)
+		result := staleResults[i]
+		stale, err := result.stale, result.err
+		if result.pinnedTag != "" {
+			log.Debugf("Pinning container %s to image tag %s", targetContainer.Name(), result.pinnedTag)
+		}
 		shouldUpdate := stale && !params.NoRestart && !params.MonitorOnly && !targetContainer.IsMonitorOnly()
 		if err == nil && shouldUpdate {
 			// Check to make sure we have all the necessary information for recreating the container
@@ -79,10 +198,14 @@ func Update(client container.Client, params types.UpdateParams) (*metrics2.Metri
 	}
 
 	if params.RollingRestart {
-		metric.Failed += performRollingRestart(containersToUpdate, client, params)
+		failed, rollbackFailed := performRollingRestart(containersToUpdate, client, params)
+		metric.Failed += failed
+		metric.RollbackFailed += rollbackFailed
 	} else {
 		metric.Failed += stopContainersInReversedOrder(containersToUpdate, client, params)
-		metric.Failed += restartContainersInSortedOrder(containersToUpdate, client, params)
+		failed, rollbackFailed := restartContainersInSortedOrder(containersToUpdate, client, params)
+		metric.Failed += failed
+		metric.RollbackFailed += rollbackFailed
 	}
 
 	metric.Updated = staleCount - (metric.Failed - staleCheckFailed)
@@ -93,17 +216,22 @@ func Update(client container.Client, params types.UpdateParams) (*metrics2.Metri
 	return metric, nil
 }
 
-func performRollingRestart(containers []container.Container, client container.Client, params types.UpdateParams) int {
+func performRollingRestart(containers []container.Container, client container.Client, params types.UpdateParams) (int, int) {
 	cleanupImageIDs := make(map[string]bool)
 	failed := 0
+	rollbackFailed := 0
 
 	for i := len(containers) - 1; i >= 0; i-- {
 		if containers[i].ToRestart() {
 			if err := stopStaleContainer(containers[i], client, params); err != nil {
 				failed++
+				continue
 			}
-			if err := restartStaleContainer(containers[i], client, params); err != nil {
+			if err, rollbackErr := restartStaleContainer(containers[i], client, params); err != nil {
 				failed++
+				if rollbackErr {
+					rollbackFailed++
+				}
 			}
 			cleanupImageIDs[containers[i].ImageID()] = true
 		}
@@ -112,7 +240,7 @@ func performRollingRestart(containers []container.Container, client container.Cl
 	if params.Cleanup {
 		cleanupImages(client, cleanupImageIDs)
 	}
-	return failed
+	return failed, rollbackFailed
 }
 
 func stopContainersInReversedOrder(containers []container.Container, client container.Client, params types.UpdateParams) int {
@@ -149,17 +277,21 @@ func stopStaleContainer(container container.Container, client container.Client,
 	return nil
 }
 
-func restartContainersInSortedOrder(containers []container.Container, client container.Client, params types.UpdateParams) int {
+func restartContainersInSortedOrder(containers []container.Container, client container.Client, params types.UpdateParams) (int, int) {
 	imageIDs := make(map[string]bool)
 
 	failed := 0
+	rollbackFailed := 0
 
 	for _, c := range containers {
 		if !c.ToRestart() {
 			continue
 		}
-		if err := restartStaleContainer(c, client, params); err != nil {
+		if err, rollbackErr := restartStaleContainer(c, client, params); err != nil {
 			failed++
+			if rollbackErr {
+				rollbackFailed++
+			}
 		}
 		imageIDs[c.ImageID()] = true
 	}
@@ -168,7 +300,7 @@ func restartContainersInSortedOrder(containers []container.Container, client con
 		cleanupImages(client, imageIDs)
 	}
 
-	return failed
+	return failed, rollbackFailed
 }
 
 func cleanupImages(client container.Client, imageIDs map[string]bool) {
@@ -179,7 +311,13 @@ func cleanupImages(client container.Client, imageIDs map[string]bool) {
 	}
 }
 
-func restartStaleContainer(container container.Container, client container.Client, params types.UpdateParams) error {
+// restartStaleContainer starts the replacement for a stopped, stale
+// container. When params.HealthTimeout is set, the replacement is given a
+// chance to report healthy before it's trusted: if it doesn't, the previous
+// image is rolled back to in its place. The returned bool reports whether a
+// rollback was attempted and failed, so callers can track that separately
+// from an ordinary update failure.
+func restartStaleContainer(container container.Container, client container.Client, params types.UpdateParams) (error, bool) {
 	// Since we can't shutdown a watchtower container immediately, we need to
 	// start the new one while the old one is still running. This prevents us
 	// from re-using the same container name so we first rename the current
@@ -187,19 +325,48 @@ func restartStaleContainer(container container.Container, client container.Clien
 	if container.IsWatchtower() {
 		if err := client.RenameContainer(container, util.RandName()); err != nil {
 			log.Error(err)
-			return nil
+			return nil, false
 		}
 	}
 
-	if !params.NoRestart {
-		if newContainerID, err := client.StartContainer(container); err != nil {
-			log.Error(err)
-			return err
-		} else if container.ToRestart() && params.LifecycleHooks {
-			lifecycle.ExecutePostUpdateCommand(client, newContainerID)
+	if params.NoRestart {
+		return nil, false
+	}
+
+	newContainerID, err := client.StartContainer(container)
+	if err != nil {
+		log.Error(err)
+		return err, false
+	}
+
+	if container.ToRestart() && params.LifecycleHooks {
+		lifecycle.ExecutePostUpdateCommand(client, newContainerID)
+	}
+
+	if params.HealthTimeout > 0 {
+		if healthErr := client.WaitForContainerHealthy(newContainerID, params.HealthTimeout); healthErr != nil {
+			log.Warnf("New container for %s did not become healthy: %v. Rolling back to previous image.", container.Name(), healthErr)
+			// RollbackContainer is responsible for stopping/removing the
+			// unhealthy replacement (identified by newContainerID, which
+			// StartContainer always created as a distinct container from
+			// the original) before restoring and restarting container's
+			// still-intact previous instance.
+			if rollbackErr := client.RollbackContainer(container, newContainerID); rollbackErr != nil {
+				log.Errorf("Rollback of %s failed: %v", container.Name(), rollbackErr)
+				return healthErr, true
+			}
+			return healthErr, false
 		}
 	}
-	return nil
+
+	// The replacement has started (and, if requested, proven healthy); the
+	// previous container StartContainer kept stopped and renamed aside is
+	// no longer needed.
+	if err := client.RemoveOldContainer(container); err != nil {
+		log.Warnf("Failed to remove previous container for %s: %v", container.Name(), err)
+	}
+
+	return nil, false
 }
 
 func checkDependencies(containers []container.Container) {
@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/containrrr/watchtower/pkg/container"
+	"github.com/containrrr/watchtower/pkg/types"
+)
+
+func TestRegistryHostname(t *testing.T) {
+	cases := map[string]string{
+		"nginx":                           "index.docker.io",
+		"library/nginx":                   "index.docker.io",
+		"myregistry.example.com/app":      "myregistry.example.com",
+		"myregistry.example.com:5000/app": "myregistry.example.com:5000",
+		"localhost/app":                   "localhost",
+	}
+
+	for imageName, want := range cases {
+		if got := registryHostname(imageName); got != want {
+			t.Errorf("registryHostname(%q) = %q, want %q", imageName, got, want)
+		}
+	}
+}
+
+func TestCheckConcurrency_HonorsOverride(t *testing.T) {
+	if got := checkConcurrency(types.UpdateParams{CheckConcurrency: 3}); got != 3 {
+		t.Errorf("checkConcurrency with override = %d, want 3", got)
+	}
+}
+
+func TestCheckConcurrency_DefaultIsBounded(t *testing.T) {
+	got := checkConcurrency(types.UpdateParams{})
+	if got < 1 || got > maxCheckConcurrency {
+		t.Errorf("checkConcurrency default = %d, want between 1 and %d", got, maxCheckConcurrency)
+	}
+}
+
+// checkStaleClient is a minimal container.Client fake for exercising
+// checkStale's pinned-tag path without touching the registry.
+type checkStaleClient struct {
+	rollbackSpyClient
+	pulledTag string
+}
+
+func (c *checkStaleClient) PullImageTag(_ container.Container, tag string) error {
+	c.pulledTag = tag
+	return nil
+}
+
+func TestCheckStale_ReportsPinnedTag(t *testing.T) {
+	client := &checkStaleClient{}
+	c := newTestContainer("id", "/aidriver")
+
+	result := checkStale(c, client, types.UpdateParams{ImageTags: map[string]string{"aidriver": "myrepo/aidriver:v2"}})
+
+	if result.err != nil {
+		t.Fatalf("unexpected error: %v", result.err)
+	}
+	if !result.stale {
+		t.Fatal("expected a pinned tag to be reported as stale so it's applied")
+	}
+	if result.pinnedTag != "myrepo/aidriver:v2" {
+		t.Fatalf("pinnedTag = %q, want %q", result.pinnedTag, "myrepo/aidriver:v2")
+	}
+	if client.pulledTag != "myrepo/aidriver:v2" {
+		t.Fatalf("PullImageTag called with %q, want %q", client.pulledTag, "myrepo/aidriver:v2")
+	}
+}
@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/containrrr/watchtower/pkg/container"
+	"github.com/containrrr/watchtower/pkg/types"
+	dockerTypes "github.com/docker/docker/api/types"
+	dockerContainerTypes "github.com/docker/docker/api/types/container"
+)
+
+// rollbackSpyClient is a minimal container.Client fake that records whether
+// restartStaleContainer rolls back (and with which replacement container ID)
+// or removes the previous container.
+type rollbackSpyClient struct {
+	newContainerID  string
+	healthErr       error
+	rollbackErr     error
+	rollbackGotID   string
+	rollbackCalled  bool
+	removeOldCalled bool
+}
+
+func (c *rollbackSpyClient) ListContainers(container.Filter) ([]container.Container, error) {
+	return nil, nil
+}
+func (c *rollbackSpyClient) StopContainer(container.Container, time.Duration) error { return nil }
+func (c *rollbackSpyClient) StartContainer(container.Container) (string, error) {
+	return c.newContainerID, nil
+}
+func (c *rollbackSpyClient) RenameContainer(container.Container, string) error { return nil }
+func (c *rollbackSpyClient) IsContainerStale(container.Container) (bool, error) {
+	return false, nil
+}
+func (c *rollbackSpyClient) PullImageTag(container.Container, string) error { return nil }
+func (c *rollbackSpyClient) RemoveImageByID(string) error                   { return nil }
+func (c *rollbackSpyClient) WaitForContainerHealthy(string, time.Duration) error {
+	return c.healthErr
+}
+func (c *rollbackSpyClient) RollbackContainer(prev container.Container, failedContainerID string) error {
+	c.rollbackCalled = true
+	c.rollbackGotID = failedContainerID
+	return c.rollbackErr
+}
+func (c *rollbackSpyClient) RemoveOldContainer(container.Container) error {
+	c.removeOldCalled = true
+	return nil
+}
+
+func newTestContainer(id, name string) container.Container {
+	return container.NewContainer(dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{ID: id, Name: name},
+		Config:            &dockerContainerTypes.Config{Image: "myrepo/app:v1"},
+	}, nil)
+}
+
+func TestRestartStaleContainer_RollsBackReplacementOnUnhealthy(t *testing.T) {
+	client := &rollbackSpyClient{
+		newContainerID: "new-container-id",
+		healthErr:      errors.New("never became healthy"),
+	}
+	prev := newTestContainer("old-container-id", "/app")
+
+	err, rollbackFailed := restartStaleContainer(prev, client, types.UpdateParams{HealthTimeout: time.Second})
+
+	if err == nil {
+		t.Fatal("expected an error when the replacement never becomes healthy")
+	}
+	if rollbackFailed {
+		t.Fatal("rollback succeeded, so rollbackFailed should be false")
+	}
+	if !client.rollbackCalled {
+		t.Fatal("expected RollbackContainer to be called")
+	}
+	if client.rollbackGotID != "new-container-id" {
+		t.Fatalf("RollbackContainer got container id %q, want the new replacement's id %q", client.rollbackGotID, "new-container-id")
+	}
+}
+
+func TestRestartStaleContainer_RollbackFailureIsReported(t *testing.T) {
+	client := &rollbackSpyClient{
+		newContainerID: "new-container-id",
+		healthErr:      errors.New("never became healthy"),
+		rollbackErr:    errors.New("previous image is gone"),
+	}
+	prev := newTestContainer("old-container-id", "/app")
+
+	err, rollbackFailed := restartStaleContainer(prev, client, types.UpdateParams{HealthTimeout: time.Second})
+
+	if err == nil {
+		t.Fatal("expected an error when the replacement never becomes healthy")
+	}
+	if !rollbackFailed {
+		t.Fatal("expected rollbackFailed to be true when RollbackContainer itself errors")
+	}
+}
+
+func TestRestartStaleContainer_SkipsHealthGateWhenTimeoutUnset(t *testing.T) {
+	client := &rollbackSpyClient{newContainerID: "new-container-id"}
+	prev := newTestContainer("old-container-id", "/app")
+
+	err, rollbackFailed := restartStaleContainer(prev, client, types.UpdateParams{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rollbackFailed {
+		t.Fatal("rollbackFailed should be false when no rollback was attempted")
+	}
+	if client.rollbackCalled {
+		t.Fatal("RollbackContainer should not be called when HealthTimeout is unset")
+	}
+	if !client.removeOldCalled {
+		t.Fatal("expected the previous container to be cleaned up once the replacement started")
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 
+	"github.com/containrrr/watchtower/pkg/container"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -12,8 +14,11 @@ var (
 	lock chan bool
 )
 
-// New is a factory function creating a new  Handler instance
-func New(updateFn func(map[string]string), updateLock chan bool) *Handler {
+// New is a factory function creating a new  Handler instance. listContainers,
+// when non-nil, is used by GET to report the containers watchtower is
+// actually tracking and the image each currently resolves to; if it's nil,
+// GET falls back to reporting only the overrides from the last POST.
+func New(updateFn func(map[string]string), updateLock chan bool, listContainers func() ([]container.Container, error)) *Handler {
 	if updateLock != nil {
 		lock = updateLock
 	} else {
@@ -22,8 +27,9 @@ func New(updateFn func(map[string]string), updateLock chan bool) *Handler {
 	}
 
 	return &Handler{
-		fn:   updateFn,
-		Path: "/v1/update",
+		fn:             updateFn,
+		Path:           "/v1/update",
+		listContainers: listContainers,
 	}
 }
 
@@ -31,15 +37,44 @@ func New(updateFn func(map[string]string), updateLock chan bool) *Handler {
 type Handler struct {
 	fn   func(containerImageTags map[string]string)
 	Path string
+
+	// listContainers, when set, lets GET report the image currently resolved
+	// for every tracked container rather than just the last POST's overrides.
+	listContainers func() ([]container.Container, error)
+
+	// lastTargetsMu guards lastTargets, which is written by POST handlers
+	// and read by GET handlers running concurrently on separate goroutines.
+	lastTargetsMu sync.Mutex
+	// lastTargets records the container->tag overrides resolved by the most
+	// recent POST. It's applied on top of listContainers' results so GET
+	// reflects pinned overrides even before they've taken effect, and it's
+	// the sole source for GET when listContainers isn't wired in.
+	lastTargets map[string]string
 }
 
+// UpdateRequestBody is the payload accepted by POST /v1/update. Targets maps
+// a container name or label to the image it should be pinned to, e.g.
+// {"targets": {"aidriver": {"image": "myrepo/aidriver:v2"}}}.
 type UpdateRequestBody struct {
-	AiDriverTag string
-	DaemonTag   string
+	Targets map[string]ImageTarget `json:"targets"`
+}
+
+// ImageTarget describes the image reference a targeted container should be
+// updated to.
+type ImageTarget struct {
+	Image string `json:"image"`
 }
 
 // Handle is the actual http.Handle function doing all the heavy lifting
 func (handle *Handler) Handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		handle.handleGet(w)
+		return
+	}
+	handle.handlePost(w, r)
+}
+
+func (handle *Handler) handlePost(w http.ResponseWriter, r *http.Request) {
 	log.Info("Updates triggered by HTTP API request.")
 
 	var urb UpdateRequestBody
@@ -51,16 +86,71 @@ func (handle *Handler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	containerImageTags := make(map[string]string)
-	containerImageTags["aidriver"] = urb.AiDriverTag
-	containerImageTags["daemon"] = urb.DaemonTag
+	containerImageTags := make(map[string]string, len(urb.Targets))
+	for name, target := range urb.Targets {
+		containerImageTags[name] = target.Image
+	}
 
 	select {
 	case chanValue := <-lock:
 		defer func() { lock <- chanValue }()
+		handle.lastTargetsMu.Lock()
+		handle.lastTargets = containerImageTags
+		handle.lastTargetsMu.Unlock()
 		handle.fn(containerImageTags)
 	default:
 		log.Debug("Skipped. Another update already running.")
 	}
+}
+
+// handleGet reports the containers watchtower is tracking and the image
+// each currently resolves to, so operators have a way to confirm what's
+// being watched without recompiling or re-POSTing. When listContainers
+// wasn't wired in via New, it falls back to reporting only the container->tag
+// overrides resolved by the last POST.
+func (handle *Handler) handleGet(w http.ResponseWriter) {
+	handle.lastTargetsMu.Lock()
+	overrides := handle.lastTargets
+	handle.lastTargetsMu.Unlock()
+
+	targets, err := handle.resolveTargets(overrides)
+	if err != nil {
+		log.Errorf("Failed to list tracked containers. %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Targets map[string]string `json:"targets"`
+	}{Targets: targets}); err != nil {
+		log.Errorf("Failed to write tracked targets. %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// resolveTargets reports, for every container listContainers currently
+// returns, the image it's pinned to by overrides or, absent an override,
+// the image it was last started from. If listContainers isn't wired in, it
+// reports overrides alone, unable to speak to containers it was never told
+// about.
+func (handle *Handler) resolveTargets(overrides map[string]string) (map[string]string, error) {
+	if handle.listContainers == nil {
+		return overrides, nil
+	}
+
+	containers, err := handle.listContainers()
+	if err != nil {
+		return nil, err
+	}
 
+	targets := make(map[string]string, len(containers))
+	for _, c := range containers {
+		if override, ok := overrides[c.Name()]; ok {
+			targets[c.Name()] = override
+			continue
+		}
+		targets[c.Name()] = c.ImageName()
+	}
+	return targets, nil
 }
@@ -0,0 +1,124 @@
+package update
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/containrrr/watchtower/pkg/container"
+	dockerTypes "github.com/docker/docker/api/types"
+	dockerContainerTypes "github.com/docker/docker/api/types/container"
+)
+
+func newTestHandler() *Handler {
+	return New(func(map[string]string) {}, nil, nil)
+}
+
+func newTestTrackedContainer(name, image string) container.Container {
+	return container.NewContainer(dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{ID: name, Name: "/" + name},
+		Config:            &dockerContainerTypes.Config{Image: image},
+	}, nil)
+}
+
+func TestHandlePost_ParsesGenericTargets(t *testing.T) {
+	var got map[string]string
+	handle := New(func(containerImageTags map[string]string) {
+		got = containerImageTags
+	}, nil, nil)
+
+	body := bytes.NewBufferString(`{"targets": {"aidriver": {"image": "myrepo/aidriver:v2"}}}`)
+	req := httptest.NewRequest(http.MethodPost, "/v1/update", body)
+	w := httptest.NewRecorder()
+
+	handle.Handle(w, req)
+
+	if got["aidriver"] != "myrepo/aidriver:v2" {
+		t.Fatalf("expected aidriver to be pinned to myrepo/aidriver:v2, got %q", got["aidriver"])
+	}
+}
+
+// TestHandleGet_FallsBackToLastTargetsWithoutListContainers covers a Handler
+// built without listContainers (e.g. New's third argument is nil): GET can
+// only speak to the overrides it was told about via POST.
+func TestHandleGet_FallsBackToLastTargetsWithoutListContainers(t *testing.T) {
+	handle := newTestHandler()
+
+	postReq := httptest.NewRequest(http.MethodPost, "/v1/update", bytes.NewBufferString(
+		`{"targets": {"daemon": {"image": "myrepo/daemon:v3"}}}`,
+	))
+	handle.Handle(httptest.NewRecorder(), postReq)
+
+	w := httptest.NewRecorder()
+	handle.Handle(w, httptest.NewRequest(http.MethodGet, "/v1/update", nil))
+
+	var resp struct {
+		Targets map[string]string `json:"targets"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Targets["daemon"] != "myrepo/daemon:v3" {
+		t.Fatalf("expected daemon to be reported as myrepo/daemon:v3, got %q", resp.Targets["daemon"])
+	}
+}
+
+// TestHandleGet_ReportsLiveContainersWithOverridesApplied covers a Handler
+// wired with listContainers: GET must report every tracked container's
+// resolved image, not just whatever was in the last POST body.
+func TestHandleGet_ReportsLiveContainersWithOverridesApplied(t *testing.T) {
+	tracked := []container.Container{
+		newTestTrackedContainer("aidriver", "myrepo/aidriver:v1"),
+		newTestTrackedContainer("daemon", "myrepo/daemon:v1"),
+	}
+	handle := New(func(map[string]string) {}, nil, func() ([]container.Container, error) {
+		return tracked, nil
+	})
+
+	postReq := httptest.NewRequest(http.MethodPost, "/v1/update", bytes.NewBufferString(
+		`{"targets": {"daemon": {"image": "myrepo/daemon:v3"}}}`,
+	))
+	handle.Handle(httptest.NewRecorder(), postReq)
+
+	w := httptest.NewRecorder()
+	handle.Handle(w, httptest.NewRequest(http.MethodGet, "/v1/update", nil))
+
+	var resp struct {
+		Targets map[string]string `json:"targets"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Targets["aidriver"] != "myrepo/aidriver:v1" {
+		t.Fatalf("expected aidriver, never overridden, to report its running image, got %q", resp.Targets["aidriver"])
+	}
+	if resp.Targets["daemon"] != "myrepo/daemon:v3" {
+		t.Fatalf("expected daemon's override to win over its running image, got %q", resp.Targets["daemon"])
+	}
+}
+
+// TestHandle_ConcurrentGetAndPost guards against the lastTargets data race:
+// run under `go test -race` to catch a regression.
+func TestHandle_ConcurrentGetAndPost(t *testing.T) {
+	handle := newTestHandler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/v1/update", bytes.NewBufferString(
+				`{"targets": {"aidriver": {"image": "myrepo/aidriver:v2"}}}`,
+			))
+			handle.Handle(httptest.NewRecorder(), req)
+		}()
+		go func() {
+			defer wg.Done()
+			handle.Handle(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/update", nil))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,223 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	dockerContainerTypes "github.com/docker/docker/api/types/container"
+	dockerClientPkg "github.com/docker/docker/client"
+	log "github.com/sirupsen/logrus"
+)
+
+// oldContainerSuffix is appended to a container's name while its replacement
+// is being created, so the original name is free for the new container to
+// take over. The old, stopped container stays under this name until either
+// the replacement has started and, when health-gated, is confirmed healthy
+// (and it's removed) or the replacement fails health-gating (and it's rolled
+// back to).
+const oldContainerSuffix = "_watchtower_old"
+
+// Client talks to the Docker API on behalf of the update actions: listing
+// containers, deciding staleness, and recreating containers in place.
+type Client interface {
+	ListContainers(Filter) ([]Container, error)
+	StopContainer(Container, time.Duration) error
+	StartContainer(Container) (string, error)
+	RenameContainer(Container, string) error
+	IsContainerStale(Container) (bool, error)
+	PullImageTag(Container, string) error
+	RemoveImageByID(string) error
+
+	// WaitForContainerHealthy polls the replacement container's healthcheck
+	// status until it reports healthy, the timeout elapses, or the
+	// container has no healthcheck configured, in which case it's treated
+	// as healthy immediately.
+	WaitForContainerHealthy(containerID string, timeout time.Duration) error
+	// RollbackContainer stops and removes the failed replacement, then
+	// restores and restarts prev (which StartContainer left renamed aside,
+	// stopped but intact), undoing a failed update.
+	RollbackContainer(prev Container, failedContainerID string) error
+	// RemoveOldContainer removes prev once its replacement has started
+	// successfully and no longer needs it kept around for rollback.
+	RemoveOldContainer(prev Container) error
+}
+
+// NewClient creates a Client backed by the given Docker API client.
+func NewClient(api dockerClientPkg.APIClient) Client {
+	return dockerClient{api: api}
+}
+
+// dockerClient is the default Client implementation, backed by the Docker
+// Engine API.
+type dockerClient struct {
+	api dockerClientPkg.APIClient
+}
+
+func (client dockerClient) ListContainers(filter Filter) ([]Container, error) {
+	ctx := context.Background()
+
+	runningContainers, err := client.api.ContainerList(ctx, dockerTypes.ContainerListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]Container, 0, len(runningContainers))
+	for _, runningContainer := range runningContainers {
+		containerInfo, err := client.api.ContainerInspect(ctx, runningContainer.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		imageInfo, _, err := client.api.ImageInspectWithRaw(ctx, containerInfo.Image)
+		var imageInfoPtr *dockerTypes.ImageInspect
+		if err == nil {
+			imageInfoPtr = &imageInfo
+		}
+
+		c := NewContainer(containerInfo, imageInfoPtr)
+		if filter == nil || filter(c) {
+			containers = append(containers, c)
+		}
+	}
+
+	return containers, nil
+}
+
+func (client dockerClient) StopContainer(c Container, timeout time.Duration) error {
+	ctx := context.Background()
+	seconds := int(timeout.Seconds())
+	return client.api.ContainerStop(ctx, c.containerInfo.ID, dockerTypes.ContainerStopOptions{Timeout: &seconds})
+}
+
+// StartContainer creates and starts the replacement for c, which the caller
+// has already stopped. The replacement is a genuinely new container (its own
+// ID, distinct from c's) built from c's recorded config, so that c itself
+// can be kept alongside, stopped but untouched, until the replacement is
+// confirmed healthy. Since both containers briefly exist together, c is
+// renamed out of the way first to free up its name for the replacement.
+func (client dockerClient) StartContainer(c Container) (string, error) {
+	ctx := context.Background()
+
+	if err := client.api.ContainerRename(ctx, c.containerInfo.ID, c.Name()+oldContainerSuffix); err != nil {
+		return "", fmt.Errorf("failed to free up name for %s: %w", c.Name(), err)
+	}
+
+	created, err := client.api.ContainerCreate(ctx, c.containerInfo.Config, c.containerInfo.HostConfig, nil, nil, c.Name())
+	if err != nil {
+		if renameErr := client.api.ContainerRename(ctx, c.containerInfo.ID, c.Name()); renameErr != nil {
+			log.Errorf("Failed to restore name for %s after a failed recreate: %v", c.Name(), renameErr)
+		}
+		return "", err
+	}
+
+	if err := client.api.ContainerStart(ctx, created.ID, dockerTypes.ContainerStartOptions{}); err != nil {
+		return "", err
+	}
+
+	return created.ID, nil
+}
+
+func (client dockerClient) RenameContainer(c Container, newName string) error {
+	return client.api.ContainerRename(context.Background(), c.containerInfo.ID, newName)
+}
+
+func (client dockerClient) IsContainerStale(c Container) (bool, error) {
+	ctx := context.Background()
+
+	reader, err := client.api.ImagePull(ctx, c.ImageName(), dockerTypes.ImagePullOptions{})
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close()
+
+	newImageInfo, _, err := client.api.ImageInspectWithRaw(ctx, c.ImageName())
+	if err != nil {
+		return false, err
+	}
+
+	return c.imageInfo == nil || newImageInfo.ID != c.imageInfo.ID, nil
+}
+
+func (client dockerClient) PullImageTag(c Container, tag string) error {
+	ctx := context.Background()
+
+	reader, err := client.api.ImagePull(ctx, tag, dockerTypes.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull %s for container %s: %w", tag, c.Name(), err)
+	}
+	defer reader.Close()
+
+	return nil
+}
+
+func (client dockerClient) RemoveImageByID(imageID string) error {
+	_, err := client.api.ImageRemove(context.Background(), imageID, dockerTypes.ImageRemoveOptions{Force: true})
+	return err
+}
+
+func (client dockerClient) WaitForContainerHealthy(containerID string, timeout time.Duration) error {
+	ctx := context.Background()
+	deadline := time.Now().Add(timeout)
+
+	for {
+		info, err := client.api.ContainerInspect(ctx, containerID)
+		if err != nil {
+			return err
+		}
+
+		if info.State == nil || info.State.Health == nil {
+			// No healthcheck configured; nothing to gate on.
+			return nil
+		}
+
+		switch info.State.Health.Status {
+		case "healthy":
+			return nil
+		case "unhealthy":
+			return fmt.Errorf("container %s reported unhealthy", containerID)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for container %s to become healthy", timeout, containerID)
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+// RollbackContainer undoes a failed update: failedContainerID (the
+// replacement StartContainer created) is stopped and removed, and prev
+// (still present, stopped, under its renamed-aside name) is restored to its
+// original name and restarted. failedContainerID is always distinct from
+// prev's own ID, since StartContainer only ever creates a new container for
+// the replacement, never reuses prev's.
+func (client dockerClient) RollbackContainer(prev Container, failedContainerID string) error {
+	ctx := context.Background()
+
+	log.Infof("Rolling back %s to previous image %s", prev.Name(), prev.ImageID())
+
+	if err := client.api.ContainerStop(ctx, failedContainerID, dockerTypes.ContainerStopOptions{}); err != nil {
+		log.Warnf("Failed to stop unhealthy replacement %s during rollback: %v", failedContainerID, err)
+	}
+	if err := client.api.ContainerRemove(ctx, failedContainerID, dockerTypes.ContainerRemoveOptions{Force: true}); err != nil {
+		log.Warnf("Failed to remove unhealthy replacement %s during rollback: %v", failedContainerID, err)
+	}
+
+	if err := client.api.ContainerRename(ctx, prev.containerInfo.ID, prev.Name()); err != nil {
+		return fmt.Errorf("failed to restore name for previous container %s during rollback: %w", prev.Name(), err)
+	}
+	if err := client.api.ContainerStart(ctx, prev.containerInfo.ID, dockerTypes.ContainerStartOptions{}); err != nil {
+		return fmt.Errorf("failed to restart previous container %s during rollback: %w", prev.Name(), err)
+	}
+
+	return nil
+}
+
+// RemoveOldContainer removes prev, which StartContainer left stopped and
+// renamed aside, once its replacement is confirmed healthy (or didn't need
+// health-gating) and prev is no longer needed for rollback.
+func (client dockerClient) RemoveOldContainer(prev Container) error {
+	return client.api.ContainerRemove(context.Background(), prev.containerInfo.ID, dockerTypes.ContainerRemoveOptions{Force: true})
+}
@@ -0,0 +1,169 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dockerTypes "github.com/docker/docker/api/types"
+	dockerContainerTypes "github.com/docker/docker/api/types/container"
+	dockerClientPkg "github.com/docker/docker/client"
+)
+
+// fakeAPIClient is a minimal fake of the Docker Engine API. It embeds the
+// real client interface (left nil) so it satisfies dockerClientPkg.APIClient
+// without implementing every method; it overrides only the calls dockerClient
+// actually makes, tracked here so tests can assert on them directly instead
+// of going through the container.Client interface (and its test fakes) the
+// way internal/actions's tests do.
+type fakeAPIClient struct {
+	dockerClientPkg.APIClient
+
+	containers map[string]*dockerTypes.ContainerJSON
+	nextID     int
+
+	started []string
+	stopped []string
+	removed []string
+	renamed []renameCall
+}
+
+type renameCall struct {
+	containerID string
+	newName     string
+}
+
+func newFakeAPIClient(existing ...dockerTypes.ContainerJSON) *fakeAPIClient {
+	f := &fakeAPIClient{containers: map[string]*dockerTypes.ContainerJSON{}}
+	for i := range existing {
+		c := existing[i]
+		f.containers[c.ID] = &c
+	}
+	return f
+}
+
+func (f *fakeAPIClient) ContainerCreate(
+	_ context.Context,
+	config *dockerContainerTypes.Config,
+	hostConfig *dockerContainerTypes.HostConfig,
+	_ interface{},
+	_ interface{},
+	name string,
+) (dockerContainerTypes.CreateResponse, error) {
+	f.nextID++
+	id := fmt.Sprintf("new-container-%d", f.nextID)
+	f.containers[id] = &dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{ID: id, Name: "/" + name},
+		Config:            config,
+	}
+	_ = hostConfig
+	return dockerContainerTypes.CreateResponse{ID: id}, nil
+}
+
+func (f *fakeAPIClient) ContainerStart(_ context.Context, containerID string, _ dockerTypes.ContainerStartOptions) error {
+	f.started = append(f.started, containerID)
+	return nil
+}
+
+func (f *fakeAPIClient) ContainerStop(_ context.Context, containerID string, _ dockerTypes.ContainerStopOptions) error {
+	f.stopped = append(f.stopped, containerID)
+	return nil
+}
+
+func (f *fakeAPIClient) ContainerRemove(_ context.Context, containerID string, _ dockerTypes.ContainerRemoveOptions) error {
+	f.removed = append(f.removed, containerID)
+	delete(f.containers, containerID)
+	return nil
+}
+
+func (f *fakeAPIClient) ContainerRename(_ context.Context, containerID, newName string) error {
+	f.renamed = append(f.renamed, renameCall{containerID: containerID, newName: newName})
+	if c, ok := f.containers[containerID]; ok {
+		c.Name = "/" + newName
+	}
+	return nil
+}
+
+func (f *fakeAPIClient) ContainerInspect(_ context.Context, containerID string) (dockerTypes.ContainerJSON, error) {
+	c, ok := f.containers[containerID]
+	if !ok {
+		return dockerTypes.ContainerJSON{}, fmt.Errorf("no such container: %s", containerID)
+	}
+	return *c, nil
+}
+
+// TestDockerClient_StartContainer_CreatesADistinctReplacement guards against
+// StartContainer merely restarting the same container ID: the replacement
+// must be a separate container so the original can still be rolled back to.
+func TestDockerClient_StartContainer_CreatesADistinctReplacement(t *testing.T) {
+	prev := dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{ID: "prev-id", Name: "/app"},
+		Config:            &dockerContainerTypes.Config{Image: "myrepo/app:v2"},
+	}
+	api := newFakeAPIClient(prev)
+	client := NewClient(api)
+
+	newID, err := client.StartContainer(NewContainer(prev, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if newID == "prev-id" {
+		t.Fatal("StartContainer must create a genuinely new container, not restart the previous one")
+	}
+	if len(api.started) != 1 || api.started[0] != newID {
+		t.Fatalf("expected the new container to be started, got started=%v", api.started)
+	}
+
+	// The previous container must still exist (stopped, renamed aside), not
+	// be removed, so a failed health check has something real to roll back to.
+	if _, ok := api.containers["prev-id"]; !ok {
+		t.Fatal("the previous container must still exist after StartContainer")
+	}
+	if got := api.containers["prev-id"].Name; got != "/app_watchtower_old" {
+		t.Fatalf("previous container name = %q, want it renamed aside", got)
+	}
+}
+
+// TestDockerClient_RollbackContainer_RemovesReplacementAndRestoresPrevious
+// exercises the real dockerClient rollback path end to end: it must remove
+// the *new* container (not the original) and must restore and restart the
+// original rather than trying to recreate something that no longer exists.
+func TestDockerClient_RollbackContainer_RemovesReplacementAndRestoresPrevious(t *testing.T) {
+	prevJSON := dockerTypes.ContainerJSON{
+		ContainerJSONBase: &dockerTypes.ContainerJSONBase{ID: "prev-id", Name: "/app"},
+		Config:            &dockerContainerTypes.Config{Image: "myrepo/app:v1"},
+	}
+	api := newFakeAPIClient(prevJSON)
+	client := NewClient(api)
+
+	prev := NewContainer(prevJSON, nil)
+	newID, err := client.StartContainer(prev)
+	if err != nil {
+		t.Fatalf("unexpected error starting replacement: %v", err)
+	}
+
+	if err := client.RollbackContainer(prev, newID); err != nil {
+		t.Fatalf("unexpected error rolling back: %v", err)
+	}
+
+	if _, stillExists := api.containers[newID]; stillExists {
+		t.Fatalf("failed replacement %s should have been removed by rollback", newID)
+	}
+	if _, ok := api.containers["prev-id"]; !ok {
+		t.Fatal("the previous container must still exist after rollback")
+	}
+	if got := api.containers["prev-id"].Name; got != "/app" {
+		t.Fatalf("previous container name after rollback = %q, want restored to /app", got)
+	}
+
+	foundRestart := false
+	for _, id := range api.started {
+		if id == "prev-id" {
+			foundRestart = true
+		}
+	}
+	if !foundRestart {
+		t.Fatal("expected the previous container to be restarted during rollback")
+	}
+}
@@ -0,0 +1,112 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	dockerTypes "github.com/docker/docker/api/types"
+)
+
+// watchtowerLabel marks the container running watchtower itself, so it can
+// be renamed out of the way instead of stopped mid-update.
+const watchtowerLabel = "com.centurylinklabs.watchtower"
+
+// monitorOnlyLabel excludes a container from restarts while still letting
+// it be reported as stale.
+const monitorOnlyLabel = "com.centurylinklabs.watchtower.monitor-only"
+
+// Filter decides whether a container should be considered by watchtower.
+type Filter func(Container) bool
+
+// Container wraps the Docker API's view of a running container together
+// with the image metadata needed to decide whether it should be updated.
+type Container struct {
+	// Stale is set once IsContainerStale has run and reports whether a
+	// newer image is available for this container.
+	Stale bool
+	// LinkedToRestarting is set when a container this one depends on is
+	// scheduled to restart, so this one is recreated alongside it.
+	LinkedToRestarting bool
+
+	containerInfo dockerTypes.ContainerJSON
+	imageInfo     *dockerTypes.ImageInspect
+}
+
+// NewContainer creates a Container from the Docker API's inspect results.
+func NewContainer(containerInfo dockerTypes.ContainerJSON, imageInfo *dockerTypes.ImageInspect) Container {
+	return Container{containerInfo: containerInfo, imageInfo: imageInfo}
+}
+
+// Name returns the container's name, without its leading slash.
+func (c Container) Name() string {
+	return strings.TrimPrefix(c.containerInfo.Name, "/")
+}
+
+// ImageID returns the ID of the image the container is currently running.
+func (c Container) ImageID() string {
+	return c.containerInfo.Image
+}
+
+// ImageName returns the image reference (repo:tag) the container was
+// started from.
+func (c Container) ImageName() string {
+	if c.containerInfo.Config == nil {
+		return ""
+	}
+	return c.containerInfo.Config.Image
+}
+
+// ImageInfo returns the inspected image metadata, or nil if it hasn't been
+// fetched.
+func (c Container) ImageInfo() *dockerTypes.ImageInspect {
+	return c.imageInfo
+}
+
+// ContainerInfo returns the raw Docker API inspect result for the container.
+func (c Container) ContainerInfo() *dockerTypes.ContainerJSON {
+	return &c.containerInfo
+}
+
+// IsWatchtower reports whether this container is running watchtower itself.
+func (c Container) IsWatchtower() bool {
+	return c.hasLabel(watchtowerLabel)
+}
+
+// IsMonitorOnly reports whether this container is excluded from restarts.
+func (c Container) IsMonitorOnly() bool {
+	return c.hasLabel(monitorOnlyLabel)
+}
+
+func (c Container) hasLabel(label string) bool {
+	if c.containerInfo.Config == nil {
+		return false
+	}
+	value, ok := c.containerInfo.Config.Labels[label]
+	return ok && value == "true"
+}
+
+// ToRestart reports whether this container should be stopped and restarted
+// as part of the current update.
+func (c Container) ToRestart() bool {
+	return c.Stale || c.LinkedToRestarting
+}
+
+// Links returns the names of the containers this container depends on.
+func (c Container) Links() []string {
+	if c.containerInfo.HostConfig == nil {
+		return nil
+	}
+	return c.containerInfo.HostConfig.Links
+}
+
+// VerifyConfiguration checks that enough information was captured to
+// recreate this container from scratch.
+func (c Container) VerifyConfiguration() error {
+	if c.containerInfo.Config == nil {
+		return fmt.Errorf("container %s is missing its configuration", c.Name())
+	}
+	if c.imageInfo == nil {
+		return fmt.Errorf("container %s is missing its image information", c.Name())
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package metrics
+
+// Metric holds the outcome of a single Update run, used for logging and for
+// the Prometheus metrics endpoint.
+type Metric struct {
+	Scanned int
+	Updated int
+	Failed  int
+
+	// RollbackFailed counts containers whose health-gated update failed and
+	// whose automatic rollback to the previous image also failed, leaving
+	// the container in a worse state than a plain update failure.
+	RollbackFailed int
+}